@@ -1,7 +1,16 @@
 package main
 
-import ("C"
+// #include <stdlib.h>
+// #include <stdint.h>
+import "C"
+
+import (
+        "bytes"
         "strings"
+        "sync"
+        "sync/atomic"
+        "unicode"
+        "unsafe"
 )
 
 //export Echo
@@ -11,4 +20,150 @@ func Echo(s *C.char) *C.char {
         C.GoString(s)))
 }
 
-func main() {}
\ No newline at end of file
+// specialCases maps normalized BCP-47 locale prefixes to the unicode.SpecialCase
+// used for locale-aware casing (Turkish/Azeri dotted-I). Go's unicode package
+// has no built-in table for Lithuanian, so "lt" falls back to the ASCII-only
+// default rather than being handled here.
+var specialCases = map[string]unicode.SpecialCase{
+    "tr": unicode.TurkishCase,
+    "az": unicode.AzeriCase,
+}
+
+// localePrefix normalizes a BCP-47 tag ("tr-TR") down to its primary
+// language subtag ("tr") for lookup in specialCases.
+func localePrefix(locale string) string {
+    locale = strings.ToLower(locale)
+    if i := strings.IndexAny(locale, "-_"); i >= 0 {
+        locale = locale[:i]
+    }
+    return locale
+}
+
+//export EchoUpper
+func EchoUpper(locale, s *C.char) *C.char {
+    str := C.GoString(s)
+    special, ok := specialCases[localePrefix(C.GoString(locale))]
+    if !ok {
+        return C.CString(strings.ToUpper(str))
+    }
+    return C.CString(strings.ToUpperSpecial(special, str))
+}
+
+//export EchoLower
+func EchoLower(locale, s *C.char) *C.char {
+    str := C.GoString(s)
+    special, ok := specialCases[localePrefix(C.GoString(locale))]
+    if !ok {
+        return C.CString(strings.ToLower(str))
+    }
+    return C.CString(strings.ToLowerSpecial(special, str))
+}
+
+//export EchoTitle
+func EchoTitle(locale, s *C.char) *C.char {
+    str := C.GoString(s)
+    special, ok := specialCases[localePrefix(C.GoString(locale))]
+    if !ok {
+        return C.CString(strings.ToTitle(str))
+    }
+    return C.CString(strings.ToTitleSpecial(special, str))
+}
+
+// shimVersion lets consumers feature-detect the symbols exported by this
+// build of the shim (case-conversion, free, and session helpers).
+const shimVersion = "1.1.0"
+
+//export Version
+func Version() *C.char {
+    return C.CString(shimVersion)
+}
+
+//export FreeCString
+func FreeCString(p *C.char) {
+    C.free(unsafe.Pointer(p))
+}
+
+// session guards the C strings handed out under one handle with its own
+// mutex, so concurrent SessionEcho calls on the same handle can't race each
+// other's read-modify-write of the slice.
+type session struct {
+    mu   sync.Mutex
+    strs []*C.char
+}
+
+// sessions pools the strings handed out by SessionEcho, keyed by an opaque
+// handle, so a caller can bulk-free everything it received once its
+// transaction ends instead of freeing each pointer individually.
+var sessions sync.Map // uintptr -> *session
+
+var nextHandle uintptr
+
+//export NewSession
+func NewSession() C.uintptr_t {
+    handle := atomic.AddUintptr(&nextHandle, 1)
+    sessions.Store(handle, &session{})
+    return C.uintptr_t(handle)
+}
+
+// sessionFor returns the session for handle, transparently creating one for
+// an unknown or already-freed handle instead of panicking, since crashing
+// the host process is worse than treating the call as a fresh session.
+func sessionFor(handle uintptr) *session {
+    actual, _ := sessions.LoadOrStore(handle, &session{})
+    return actual.(*session)
+}
+
+//export SessionEcho
+func SessionEcho(handle C.uintptr_t, s *C.char) *C.char {
+    out := C.CString(strings.ToUpper(C.GoString(s)))
+    sess := sessionFor(uintptr(handle))
+    sess.mu.Lock()
+    sess.strs = append(sess.strs, out)
+    sess.mu.Unlock()
+    return out
+}
+
+//export FreeSession
+func FreeSession(handle C.uintptr_t) {
+    key := uintptr(handle)
+    val, ok := sessions.Load(key)
+    if !ok {
+        return
+    }
+    sess := val.(*session)
+    sess.mu.Lock()
+    for _, p := range sess.strs {
+        C.free(unsafe.Pointer(p))
+    }
+    sess.strs = nil
+    sess.mu.Unlock()
+    sessions.Delete(key)
+}
+
+// EchoMany converts n NUL-terminated strings in a single cgo crossing,
+// writing each result into the caller-provided outPtr array. Ownership: the
+// caller must free every pointer written to outPtr, e.g. with FreeCString
+// or by routing them through a SessionEcho-managed handle instead.
+//
+//export EchoMany
+func EchoMany(inPtr **C.char, n C.int, outPtr **C.char) {
+    in := unsafe.Slice(inPtr, int(n))
+    out := unsafe.Slice(outPtr, int(n))
+    for i := 0; i < int(n); i++ {
+        out[i] = C.CString(strings.ToUpper(C.GoString(in[i])))
+    }
+}
+
+// EchoBytes converts a length-prefixed byte blob without the implicit
+// strlen scan C.GoString/C.CString pay, which matters once blobs are large
+// or contain embedded NULs. Ownership: the caller must free the returned
+// pointer, e.g. with FreeCString.
+//
+//export EchoBytes
+func EchoBytes(buf *C.char, length C.int, outLen *C.int) *C.char {
+    upper := bytes.ToUpper(C.GoBytes(unsafe.Pointer(buf), length))
+    *outLen = C.int(len(upper))
+    return (*C.char)(C.CBytes(upper))
+}
+
+func main() {}
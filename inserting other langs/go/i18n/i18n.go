@@ -0,0 +1,72 @@
+package main
+
+import (
+	"C"
+	"path/filepath"
+	"sync"
+)
+
+// domainDirs maps a bound text domain to the directory BindTextdomain gave
+// it, mirroring gettext's bindtextdomain(3).
+var domainDirs sync.Map // string -> string
+
+// catalogs caches loaded catalogs per (domain) so repeated Gettext/NGettext
+// calls don't re-parse the .po/.mo file on every crossing.
+var catalogs sync.Map // string -> *catalog
+
+//export BindTextdomain
+func BindTextdomain(domain, dir *C.char) {
+	domainDirs.Store(C.GoString(domain), C.GoString(dir))
+	catalogs.Delete(C.GoString(domain))
+}
+
+// loadDomain resolves and caches the catalog bound to domain, preferring a
+// compiled domain.mo over a source domain.po in the bound directory.
+func loadDomain(domain string) *catalog {
+	if cached, ok := catalogs.Load(domain); ok {
+		return cached.(*catalog)
+	}
+
+	dir, ok := domainDirs.Load(domain)
+	if !ok {
+		cat := newCatalog()
+		catalogs.Store(domain, cat)
+		return cat
+	}
+
+	base := filepath.Join(dir.(string), domain)
+	cat, err := loadMO(base + ".mo")
+	if err != nil {
+		cat, err = loadPO(base + ".po")
+	}
+	if err != nil {
+		cat = newCatalog()
+	}
+	catalogs.Store(domain, cat)
+	return cat
+}
+
+//export Gettext
+func Gettext(domain, msgid *C.char) *C.char {
+	id := C.GoString(msgid)
+	cat := loadDomain(C.GoString(domain))
+	if translated, ok := cat.lookup(id, 0, false); ok {
+		return C.CString(translated)
+	}
+	return C.CString(id)
+}
+
+//export NGettext
+func NGettext(domain, msgid, msgidPlural *C.char, n C.longlong) *C.char {
+	cat := loadDomain(C.GoString(domain))
+	id, idPlural := C.GoString(msgid), C.GoString(msgidPlural)
+	if translated, ok := cat.lookup(id, int64(n), true); ok {
+		return C.CString(translated)
+	}
+	if n == 1 {
+		return C.CString(id)
+	}
+	return C.CString(idPlural)
+}
+
+func main() {}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	moMagicLE = 0x950412de
+	moMagicBE = 0xde120495
+)
+
+// loadMO parses a compiled .mo file (the GNU gettext binary catalog format)
+// into a catalog, using the same header/plural handling as loadPO.
+func loadMO(path string) (*catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 28 {
+		return nil, fmt.Errorf("i18n: %s is too short to be a .mo file", path)
+	}
+
+	var order binary.ByteOrder
+	switch binary.LittleEndian.Uint32(data[0:4]) {
+	case moMagicLE:
+		order = binary.LittleEndian
+	case moMagicBE:
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("i18n: %s is not a .mo file", path)
+	}
+
+	numStrings := order.Uint32(data[8:12])
+	origTableOffset := order.Uint32(data[12:16])
+	transTableOffset := order.Uint32(data[16:20])
+
+	readEntry := func(tableOffset, i uint32) (string, error) {
+		// Widen to uint64 before adding: tableOffset/i/length/offset are
+		// attacker-controlled uint32s, and tableOffset+i*8 or offset+length
+		// can wrap back into range in 32-bit arithmetic, turning a
+		// should-fail bounds check into an in-range slice that panics below.
+		base := uint64(tableOffset) + uint64(i)*8
+		if base+8 > uint64(len(data)) {
+			return "", fmt.Errorf("i18n: %s truncated string table", path)
+		}
+		length := order.Uint32(data[base : base+4])
+		offset := order.Uint32(data[base+4 : base+8])
+		end := uint64(offset) + uint64(length)
+		if end > uint64(len(data)) {
+			return "", fmt.Errorf("i18n: %s truncated string data", path)
+		}
+		return string(data[offset:end]), nil
+	}
+
+	cat := newCatalog()
+	for i := uint32(0); i < numStrings; i++ {
+		orig, err := readEntry(origTableOffset, i)
+		if err != nil {
+			return nil, err
+		}
+		trans, err := readEntry(transTableOffset, i)
+		if err != nil {
+			return nil, err
+		}
+
+		if orig == "" {
+			parseHeader(cat, trans)
+			continue
+		}
+
+		// A plural entry packs "singular\x00plural" as the key and
+		// "form0\x00form1\x00..." as the value.
+		if strings.Contains(orig, "\x00") {
+			ids := strings.Split(orig, "\x00")
+			forms := strings.Split(trans, "\x00")
+			for _, id := range ids {
+				cat.messages[id] = forms
+			}
+		} else {
+			cat.messages[orig] = []string{trans}
+		}
+	}
+	return cat, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildMO assembles a minimal valid .mo file: one entry with msgid/msgstr
+// "hello"/"hola" and no header.
+func buildMO(t *testing.T) []byte {
+	t.Helper()
+	const headerSize = 28
+	origStr, transStr := "hello", "hola"
+
+	origTableOff := uint32(headerSize)
+	transTableOff := origTableOff + 8 // one entry, one table slot
+	stringsOff := transTableOff + 8
+
+	buf := make([]byte, stringsOff+uint32(len(origStr)+1+len(transStr)+1))
+	binary.LittleEndian.PutUint32(buf[0:4], moMagicLE)
+	binary.LittleEndian.PutUint32(buf[4:8], 0) // revision
+	binary.LittleEndian.PutUint32(buf[8:12], 1) // numStrings
+	binary.LittleEndian.PutUint32(buf[12:16], origTableOff)
+	binary.LittleEndian.PutUint32(buf[16:20], transTableOff)
+
+	origOff := stringsOff
+	transOff := origOff + uint32(len(origStr)) + 1
+	binary.LittleEndian.PutUint32(buf[origTableOff:origTableOff+4], uint32(len(origStr)))
+	binary.LittleEndian.PutUint32(buf[origTableOff+4:origTableOff+8], origOff)
+	binary.LittleEndian.PutUint32(buf[transTableOff:transTableOff+4], uint32(len(transStr)))
+	binary.LittleEndian.PutUint32(buf[transTableOff+4:transTableOff+8], transOff)
+
+	copy(buf[origOff:], origStr)
+	copy(buf[transOff:], transStr)
+	return buf
+}
+
+func TestLoadMOParsesWellFormedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domain.mo")
+	if err := os.WriteFile(path, buildMO(t), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cat, err := loadMO(path)
+	if err != nil {
+		t.Fatalf("loadMO: %v", err)
+	}
+	forms, ok := cat.messages["hello"]
+	if !ok || forms[0] != "hola" {
+		t.Fatalf("messages[hello] = %v, ok=%v, want [hola]", forms, ok)
+	}
+}
+
+// TestLoadMORejectsOverflowingOffsets crafts a string-table entry whose
+// offset/length sum overflows uint32 and wraps back into range, which used
+// to slip past the bounds check and panic in the slice expression.
+func TestLoadMORejectsOverflowingOffsets(t *testing.T) {
+	data := buildMO(t)
+	const headerSize = 28
+	origTableOff := uint32(headerSize)
+
+	// Point length/offset so offset+length overflows uint32 but would
+	// appear in-range if the bounds check used 32-bit arithmetic.
+	binary.LittleEndian.PutUint32(data[origTableOff:origTableOff+4], 0xFFFFFFF0)
+	binary.LittleEndian.PutUint32(data[origTableOff+4:origTableOff+8], 0x20)
+
+	path := filepath.Join(t.TempDir(), "domain.mo")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadMO(path); err == nil {
+		t.Fatal("expected an error for overflowing string-table offsets, got nil")
+	}
+}
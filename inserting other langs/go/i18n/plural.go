@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pluralExpr is a compiled node from a gettext "Plural-Forms:" expression,
+// e.g. "nplurals=3; plural=(n%10==1 && n%100!=11) ? 0 : ((n%10>=2 && n%10<=4 && (n%100<12 || n%100>14)) ? 1 : 2);"
+type pluralExpr interface {
+	eval(n int64) int64
+}
+
+type nVar struct{}
+
+func (nVar) eval(n int64) int64 { return n }
+
+type intLit int64
+
+func (l intLit) eval(int64) int64 { return int64(l) }
+
+type ternaryExpr struct{ cond, then, els pluralExpr }
+
+func (t ternaryExpr) eval(n int64) int64 {
+	if t.cond.eval(n) != 0 {
+		return t.then.eval(n)
+	}
+	return t.els.eval(n)
+}
+
+type binExpr struct {
+	op   string
+	l, r pluralExpr
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (b binExpr) eval(n int64) int64 {
+	l, r := b.l.eval(n), b.r.eval(n)
+	switch b.op {
+	case "||":
+		return boolToInt(l != 0 || r != 0)
+	case "&&":
+		return boolToInt(l != 0 && r != 0)
+	case "==":
+		return boolToInt(l == r)
+	case "!=":
+		return boolToInt(l != r)
+	case "<":
+		return boolToInt(l < r)
+	case "<=":
+		return boolToInt(l <= r)
+	case ">":
+		return boolToInt(l > r)
+	case ">=":
+		return boolToInt(l >= r)
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/":
+		if r == 0 {
+			return 0
+		}
+		return l / r
+	case "%":
+		if r == 0 {
+			return 0
+		}
+		return l % r
+	}
+	panic("i18n: unknown plural operator " + b.op)
+}
+
+// pluralTokenizer splits a Plural-Forms expression into the tokens the
+// recursive-descent parser below consumes.
+type pluralTokenizer struct {
+	toks []string
+	pos  int
+}
+
+func tokenizePlural(expr string) (*pluralTokenizer, error) {
+	var toks []string
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == 'n':
+			toks = append(toks, "n")
+			i++
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(expr) && expr[j] >= '0' && expr[j] <= '9' {
+				j++
+			}
+			toks = append(toks, expr[i:j])
+			i = j
+		case strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="),
+			strings.HasPrefix(expr[i:], "<="), strings.HasPrefix(expr[i:], ">="),
+			strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"):
+			toks = append(toks, expr[i:i+2])
+			i += 2
+		case strings.ContainsRune("?:<>+-*/%()", rune(c)):
+			toks = append(toks, string(c))
+			i++
+		case c == ';':
+			i = len(expr)
+		default:
+			return nil, fmt.Errorf("i18n: unexpected character %q in plural expression", c)
+		}
+	}
+	return &pluralTokenizer{toks: toks}, nil
+}
+
+func (t *pluralTokenizer) peek() string {
+	if t.pos >= len(t.toks) {
+		return ""
+	}
+	return t.toks[t.pos]
+}
+
+func (t *pluralTokenizer) next() string {
+	tok := t.peek()
+	t.pos++
+	return tok
+}
+
+// parsePluralExpr parses the "plural=" right-hand side of a Plural-Forms
+// header into an AST, following C operator precedence: ?: < || < && <
+// equality < relational < additive < multiplicative < primary.
+func parsePluralExpr(expr string) (pluralExpr, error) {
+	t, err := tokenizePlural(expr)
+	if err != nil {
+		return nil, err
+	}
+	node, err := parseTernary(t)
+	if err != nil {
+		return nil, err
+	}
+	if t.peek() != "" {
+		return nil, fmt.Errorf("i18n: unexpected trailing token %q in plural expression", t.peek())
+	}
+	return node, nil
+}
+
+func parseTernary(t *pluralTokenizer) (pluralExpr, error) {
+	cond, err := parseOr(t)
+	if err != nil {
+		return nil, err
+	}
+	if t.peek() == "?" {
+		t.next()
+		then, err := parseTernary(t)
+		if err != nil {
+			return nil, err
+		}
+		if t.next() != ":" {
+			return nil, fmt.Errorf("i18n: expected ':' in plural expression")
+		}
+		els, err := parseTernary(t)
+		if err != nil {
+			return nil, err
+		}
+		return ternaryExpr{cond, then, els}, nil
+	}
+	return cond, nil
+}
+
+func parseOr(t *pluralTokenizer) (pluralExpr, error) {
+	left, err := parseAnd(t)
+	if err != nil {
+		return nil, err
+	}
+	for t.peek() == "||" {
+		t.next()
+		right, err := parseAnd(t)
+		if err != nil {
+			return nil, err
+		}
+		left = binExpr{"||", left, right}
+	}
+	return left, nil
+}
+
+func parseAnd(t *pluralTokenizer) (pluralExpr, error) {
+	left, err := parseEquality(t)
+	if err != nil {
+		return nil, err
+	}
+	for t.peek() == "&&" {
+		t.next()
+		right, err := parseEquality(t)
+		if err != nil {
+			return nil, err
+		}
+		left = binExpr{"&&", left, right}
+	}
+	return left, nil
+}
+
+func parseEquality(t *pluralTokenizer) (pluralExpr, error) {
+	left, err := parseRelational(t)
+	if err != nil {
+		return nil, err
+	}
+	for t.peek() == "==" || t.peek() == "!=" {
+		op := t.next()
+		right, err := parseRelational(t)
+		if err != nil {
+			return nil, err
+		}
+		left = binExpr{op, left, right}
+	}
+	return left, nil
+}
+
+func parseRelational(t *pluralTokenizer) (pluralExpr, error) {
+	left, err := parseAdditive(t)
+	if err != nil {
+		return nil, err
+	}
+	for t.peek() == "<" || t.peek() == "<=" || t.peek() == ">" || t.peek() == ">=" {
+		op := t.next()
+		right, err := parseAdditive(t)
+		if err != nil {
+			return nil, err
+		}
+		left = binExpr{op, left, right}
+	}
+	return left, nil
+}
+
+func parseAdditive(t *pluralTokenizer) (pluralExpr, error) {
+	left, err := parseMultiplicative(t)
+	if err != nil {
+		return nil, err
+	}
+	for t.peek() == "+" || t.peek() == "-" {
+		op := t.next()
+		right, err := parseMultiplicative(t)
+		if err != nil {
+			return nil, err
+		}
+		left = binExpr{op, left, right}
+	}
+	return left, nil
+}
+
+func parseMultiplicative(t *pluralTokenizer) (pluralExpr, error) {
+	left, err := parsePrimary(t)
+	if err != nil {
+		return nil, err
+	}
+	for t.peek() == "*" || t.peek() == "/" || t.peek() == "%" {
+		op := t.next()
+		right, err := parsePrimary(t)
+		if err != nil {
+			return nil, err
+		}
+		left = binExpr{op, left, right}
+	}
+	return left, nil
+}
+
+func parsePrimary(t *pluralTokenizer) (pluralExpr, error) {
+	tok := t.next()
+	switch {
+	case tok == "n":
+		return nVar{}, nil
+	case tok == "(":
+		node, err := parseTernary(t)
+		if err != nil {
+			return nil, err
+		}
+		if t.next() != ")" {
+			return nil, fmt.Errorf("i18n: expected ')' in plural expression")
+		}
+		return node, nil
+	case tok != "":
+		if v, err := strconv.ParseInt(tok, 10, 64); err == nil {
+			return intLit(v), nil
+		}
+		return nil, fmt.Errorf("i18n: unexpected token %q in plural expression", tok)
+	default:
+		return nil, fmt.Errorf("i18n: unexpected end of plural expression")
+	}
+}
+
+// defaultPluralExpr is the gettext fallback used when a catalog has no
+// Plural-Forms header: "n==1 ? 0 : 1".
+var defaultPluralExpr = ternaryExpr{
+	cond: binExpr{"==", nVar{}, intLit(1)},
+	then: intLit(0),
+	els:  intLit(1),
+}
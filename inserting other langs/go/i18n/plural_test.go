@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestParsePluralExprDefault(t *testing.T) {
+	for n, want := range map[int64]int64{0: 1, 1: 0, 2: 1, 5: 1} {
+		if got := defaultPluralExpr.eval(n); got != want {
+			t.Errorf("defaultPluralExpr.eval(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestParsePluralExprPolish(t *testing.T) {
+	expr, err := parsePluralExpr(
+		"(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2)")
+	if err != nil {
+		t.Fatalf("parsePluralExpr: %v", err)
+	}
+	cases := map[int64]int64{
+		1:  0,
+		2:  1,
+		4:  1,
+		5:  2,
+		12: 2,
+		22: 1,
+		25: 2,
+	}
+	for n, want := range cases {
+		if got := expr.eval(n); got != want {
+			t.Errorf("eval(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestParsePluralExprArabic(t *testing.T) {
+	expr, err := parsePluralExpr(
+		"(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5)")
+	if err != nil {
+		t.Fatalf("parsePluralExpr: %v", err)
+	}
+	cases := map[int64]int64{
+		0:   0,
+		1:   1,
+		2:   2,
+		3:   3,
+		10:  3,
+		11:  4,
+		99:  4,
+		100: 5,
+		101: 5,
+	}
+	for n, want := range cases {
+		if got := expr.eval(n); got != want {
+			t.Errorf("eval(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestParsePluralExprRejectsUnknownCharacter(t *testing.T) {
+	if _, err := parsePluralExpr("n == 1 ? 0 @ 1"); err == nil {
+		t.Fatal("expected an error for an unrecognized character, got nil")
+	}
+}
+
+func TestParsePluralExprRejectsTrailingGarbage(t *testing.T) {
+	if _, err := parsePluralExpr("n == 1 ? 0 : 1 ) "); err == nil {
+		t.Fatal("expected an error for a trailing unmatched token, got nil")
+	}
+}
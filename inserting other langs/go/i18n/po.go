@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// catalog holds the compiled translations and plural rule for one loaded
+// .po/.mo file.
+type catalog struct {
+	// messages maps msgid to its translated forms; index 0 is the
+	// singular, further indices are the plural forms in header order.
+	messages map[string][]string
+	plural   pluralExpr
+	nplurals int
+}
+
+func newCatalog() *catalog {
+	return &catalog{messages: make(map[string][]string), plural: defaultPluralExpr, nplurals: 2}
+}
+
+// poField identifies which field of the entry currently being parsed a bare
+// continuation line (one starting with `"`) should be appended to.
+type poField int
+
+const (
+	fieldNone poField = iota
+	fieldMsgid
+	fieldMsgidPlural
+	fieldMsgstr
+)
+
+// loadPO parses a .po file into a catalog. It understands msgid/msgstr,
+// msgid_plural/msgstr[N], wrapped continuation lines (a bare quoted string
+// that concatenates onto whichever field was last seen), and the
+// "Plural-Forms:" header carried in the msgstr of the empty msgid entry.
+func loadPO(path string) (*catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cat := newCatalog()
+	scanner := bufio.NewScanner(f)
+
+	var msgid, msgidPlural string
+	msgstrs := map[int]string{}
+	inPlural := false
+	field := fieldNone
+	msgstrIdx := 0
+
+	flush := func() {
+		if msgid == "" && msgstrs[0] != "" {
+			// The header entry: msgid "" carries metadata in msgstr.
+			parseHeader(cat, msgstrs[0])
+		} else if msgid != "" {
+			if inPlural {
+				forms := make([]string, cat.nplurals)
+				for i := 0; i < cat.nplurals; i++ {
+					forms[i] = msgstrs[i]
+				}
+				cat.messages[msgid] = forms
+				cat.messages[msgidPlural] = forms
+			} else {
+				cat.messages[msgid] = []string{msgstrs[0]}
+			}
+		}
+		msgid, msgidPlural = "", ""
+		msgstrs = map[int]string{}
+		inPlural = false
+		field = fieldNone
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "msgid_plural "):
+			msgidPlural = unquote(strings.TrimPrefix(line, "msgid_plural "))
+			inPlural = true
+			field = fieldMsgidPlural
+		case strings.HasPrefix(line, "msgid "):
+			flush()
+			msgid = unquote(strings.TrimPrefix(line, "msgid "))
+			field = fieldMsgid
+		case strings.HasPrefix(line, "msgstr["):
+			idx, val, ok := parseIndexedMsgstr(line)
+			if ok {
+				msgstrs[idx] = val
+				msgstrIdx = idx
+				field = fieldMsgstr
+			}
+		case strings.HasPrefix(line, "msgstr "):
+			msgstrs[0] = unquote(strings.TrimPrefix(line, "msgstr "))
+			msgstrIdx = 0
+			field = fieldMsgstr
+		case strings.HasPrefix(line, `"`):
+			// A wrapped continuation of whichever field came before it.
+			switch field {
+			case fieldMsgid:
+				msgid += unquote(line)
+			case fieldMsgidPlural:
+				msgidPlural += unquote(line)
+			case fieldMsgstr:
+				msgstrs[msgstrIdx] += unquote(line)
+			}
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+func parseIndexedMsgstr(line string) (int, string, bool) {
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return 0, "", false
+	}
+	idx, err := strconv.Atoi(line[len("msgstr[") : end])
+	if err != nil {
+		return 0, "", false
+	}
+	rest := strings.TrimSpace(line[end+1:])
+	return idx, unquote(rest), true
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	s = strings.ReplaceAll(s, `\n`, "\n")
+	s = strings.ReplaceAll(s, `\t`, "\t")
+	s = strings.ReplaceAll(s, `\"`, `"`)
+	return s
+}
+
+// parseHeader pulls nplurals/plural out of the "Plural-Forms:" line embedded
+// in the catalog header, falling back to defaultPluralExpr on any error.
+func parseHeader(cat *catalog, header string) {
+	for _, line := range strings.Split(header, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "Plural-Forms:") {
+			continue
+		}
+		body := strings.TrimPrefix(line, "Plural-Forms:")
+		for _, field := range strings.Split(body, ";") {
+			field = strings.TrimSpace(field)
+			switch {
+			case strings.HasPrefix(field, "nplurals="):
+				if n, err := strconv.Atoi(strings.TrimPrefix(field, "nplurals=")); err == nil {
+					cat.nplurals = n
+				}
+			case strings.HasPrefix(field, "plural="):
+				expr := strings.TrimPrefix(field, "plural=")
+				if parsed, err := parsePluralExpr(expr); err == nil {
+					cat.plural = parsed
+				}
+			}
+		}
+	}
+}
+
+// lookup resolves a msgid (and, for plural forms, the count n) against the
+// catalog, returning ("", false) on a miss so the caller can fall back to
+// the untranslated source string.
+func (c *catalog) lookup(msgid string, n int64, isPlural bool) (string, bool) {
+	forms, ok := c.messages[msgid]
+	if !ok || len(forms) == 0 {
+		return "", false
+	}
+	if !isPlural {
+		return forms[0], forms[0] != ""
+	}
+	idx := int(c.plural.eval(n))
+	if idx < 0 || idx >= len(forms) {
+		return "", false
+	}
+	return forms[idx], forms[idx] != ""
+}